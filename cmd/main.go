@@ -2,274 +2,175 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
-	"findorgpulls/lib"
-)
+	"golang.org/x/sync/errgroup"
 
-type Row struct {
-	Organization *lib.Organization
-	Repository   *lib.Repository
-	PullRequest  *lib.PullRequest
-	CreatedDate  time.Time
-	Age          time.Duration
-}
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
-func (row *Row) String() string {
-	return fmt.Sprintf("%d days | github.com/%s/%s/pull/%d: %s <%s>\n",
-		int(row.Age.Hours()/24),
-		row.Organization.Login,
-		row.Repository.Name,
-		row.PullRequest.Number,
-		row.PullRequest.Title,
-		row.PullRequest.Author.Login,
-	)
-}
+	"findorgpulls/lib"
+)
 
-func FindOrgPulls() {
+// defaultTargets is used when the config declares no targets: the
+// GitHub orgs findorgpulls has always scanned, authenticated with
+// GITHUB_TOKEN.
+func defaultTargets() []lib.Target {
 	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		fmt.Println("Failed to get github token")
-		return
-	}
-
-	doneCh := make(chan bool)
-	rowCh := make(chan *Row)
+	baseURL := os.Getenv("GITHUB_GRAPHQL_URL")
 
-	orgNames := []string{"gdbu", "hatch1fy", "hatchify", "hatch-integrations", "vroomy"}
-
-	for _, org := range orgNames {
-		org := org
-		go func() {
-			err := getRows(token, org, rowCh)
-			if err != nil {
-				fmt.Printf("error walking %s: %v", org, err)
-			} else {
-				fmt.Println("Finished with", org)
-			}
-			doneCh <- true
-		}()
+	var targets []lib.Target
+	for _, org := range []string{"gdbu", "hatch1fy", "hatchify", "hatch-integrations", "vroomy"} {
+		targets = append(targets, lib.Target{Kind: "github", BaseURL: baseURL, Token: token, Org: org})
 	}
+	return targets
+}
 
-	var rows []*Row
-	done := 0
-	for done < len(orgNames) {
-		select {
-		case row := <-rowCh:
-			rows = append(rows, row)
-
-		case <-doneCh:
-			done++
-		}
+// loadConfig unmarshals whatever viper picked up (env and
+// ~/.findorgpulls.yaml, or the --config file) into a lib.Config,
+// falling back to defaultTargets when the user hasn't declared any
+// targets. No command-line flag is bound into the Config.
+func loadConfig() (*lib.Config, error) {
+	var cfg lib.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	fmt.Printf("Found %d open pull requests\n", len(rows))
-
-	sort.Slice(rows, func(i, j int) bool {
-		return rows[i].CreatedDate.Unix() < rows[j].CreatedDate.Unix()
-	})
-
-	for _, p := range rows {
-		label := getBucketLabel(p.Age)
-		if label != "" {
-			fmt.Println(label)
+	if len(cfg.Targets) == 0 {
+		if os.Getenv("GITHUB_TOKEN") == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is not set and no targets are configured")
 		}
-
-		fmt.Printf(p.String())
+		cfg.Targets = defaultTargets()
 	}
 
-	fmt.Printf("\n\nSummary of %d PRs\n", len(rows))
-
-	var count = 0
-	for _, bucket := range buckets {
-		count += bucket.count
-		fmt.Printf("- %s: %d PRs\n", bucket.label, bucket.count)
-	}
-	fmt.Printf("- Newer than %s: %d\n", buckets[len(buckets)-1].label, len(rows)-count)
+	return &cfg, nil
 }
 
-type bucket struct {
-	olderThan time.Duration
-	label     string
-	found     bool
-	count     int
-}
+func runFindOrgPulls(cmd *cobra.Command) error {
+	if webhookURL != "" && !cmd.Flags().Changed("format") {
+		format = "webhook"
+	}
 
-const Day = time.Hour * 24
-
-var buckets = []*bucket{
-	{
-		olderThan: 365 * Day,
-		label:     "one year",
-	},
-	{
-		olderThan: 6 * 30 * Day,
-		label:     "six months",
-	},
-	{
-		olderThan: 3 * 30 * Day,
-		label:     "three months",
-	},
-	{
-		olderThan: 30 * Day,
-		label:     "one month",
-	},
-	{
-		olderThan: 7 * Day,
-		label:     "one week",
-	},
-}
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
 
-func getBucketLabel(age time.Duration) string {
-	for _, bucket := range buckets {
-		if age <= bucket.olderThan {
-			continue
-		}
+	thresholds, err := cfg.BucketThresholds()
+	if err != nil {
+		return err
+	}
 
-		bucket.count += 1
+	rows, err := NewScanner(cfg).Scan(context.Background())
+	if err != nil {
+		return err
+	}
 
-		if bucket.found {
-			return ""
-		}
+	renderer, ok := lib.Renderers[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
 
-		bucket.found = true
-		return "Older than " + bucket.label
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, rows, thresholds); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
 	}
 
-	return ""
-}
+	if webhookURL != "" {
+		return postWebhook(webhookURL, buf.Bytes())
+	}
 
-func getRows(token string, orgName string, rowCh chan *Row) error {
-	client := http.Client{}
-	query := `
-query getAllRepos($orgName: String = "hatch1fy", $after: String, $pageSize: Int!) {
-  organization(login: $orgName) {
-    login
-    repositories(first: $pageSize, orderBy: {field: NAME, direction: ASC}, after: $after) {
-      totalCount
-      nodes {
-        name
-        pullRequests(first: 10, states: OPEN) {
-          nodes {
-            number
-            title
-            author {
-              login
-            }
-            createdAt
-          }
-        }
-      }
-      edges {
-        cursor
-      }
-    }
-  }
+	fmt.Print(buf.String())
+	return nil
 }
-`
-	pageSize := 100
 
-	vars := map[string]interface{}{
-		"orgName":  orgName,
-		"after":    nil,
-		"pageSize": pageSize,
+// postWebhook sends a rendered report to a Slack/Discord incoming
+// webhook.
+func postWebhook(url string, body []byte) error {
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
 	}
+	defer resp.Body.Close()
 
-	body := map[string]interface{}{
-		"query":     query,
-		"variables": vars,
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
 	}
 
-	pageNumber := 1
-
-	now := time.Now()
-
-	for {
-		buf, err := json.Marshal(body)
-		if err != nil {
-			return fmt.Errorf("failed to marshal json: %v", err)
-		}
-
-		reader := bytes.NewReader(buf)
-		_, err = reader.Seek(0, io.SeekStart)
-		if err != nil {
-			return fmt.Errorf("failed to seek reader: %v", err)
-		}
-
-		req, err := http.NewRequest("POST", "https://api.github.com/graphql", reader)
-		if err != nil {
-			return fmt.Errorf("failed to create new request: %v", err)
-		}
-
-		req.Header.Add("Content-Type", "application/json")
-		req.Header.Add("Authorization", "token "+token)
+	return nil
+}
 
-		fmt.Printf("Getting %s repositories, page #%d\n", orgName, pageNumber)
-		res, err := client.Do(req)
-		if err != nil {
-			return fmt.Errorf("failed to make request: %v", err)
-		}
+// scan walks every target in cfg through a worker pool bounded by
+// --concurrency, applies cfg.Filter, and returns the results sorted
+// oldest-first. A failing target cancels every other in-flight target
+// and scan returns that error rather than mixing partial results into
+// a report that looks complete. It touches no package-level state, so
+// it's safe to call more than once per process.
+func scan(ctx context.Context, cfg *lib.Config) ([]*lib.Row, error) {
+	g, ctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		mu   sync.Mutex
+		rows []*lib.Row
+	)
 
-		if res.StatusCode != 200 {
-			return fmt.Errorf("github returned %d", res.StatusCode)
-		}
+	now := time.Now()
 
-		responseBody, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return errors.New("cannot read response body")
-		}
+	for _, target := range cfg.Targets {
+		target := target
 
-		var errRes lib.ErrorResponse
-		err = json.Unmarshal(responseBody, &errRes)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshal error: %v", err)
-		}
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			defer func() { <-sem }()
 
-		if errRes.Errors != nil {
-			for _, e := range errRes.Errors {
-				return fmt.Errorf("failed to make graphql request: %s", e.String())
+			provider, err := lib.NewProvider(ctx, target)
+			if err != nil {
+				return fmt.Errorf("error creating provider for %s: %w", target.Org, err)
 			}
-		}
 
-		var model lib.Response
-		err = json.Unmarshal(responseBody, &model)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshal response: %v", err)
-		}
+			pullRequests, err := provider.ListOpenPullRequests(ctx, target.Org)
+			if err != nil {
+				return fmt.Errorf("error walking %s: %w", target.Org, err)
+			}
 
-		repos := model.Data.Organization.Repositories
-		for _, repo := range repos.Nodes {
-			for _, pr := range repo.PullRequests.Nodes {
-				c, _ := time.Parse(time.RFC3339, pr.CreatedAt)
-				age := now.Sub(c)
-
-				row := &Row{
-					Organization: model.Data.Organization,
-					Repository:   repo,
-					PullRequest:  pr,
-					CreatedDate:  c,
-					Age:          age,
-				}
-				rowCh <- row
+			targetRows := make([]*lib.Row, 0, len(pullRequests))
+			for _, pr := range cfg.Filter.Apply(pullRequests) {
+				targetRows = append(targetRows, &lib.Row{
+					Org:         target.Org,
+					Repo:        pr.Repo,
+					PullRequest: pr,
+					CreatedDate: pr.CreatedAt,
+					Age:         now.Sub(pr.CreatedAt),
+				})
 			}
-		}
 
-		if len(repos.Edges) != pageSize {
-			return nil
-		}
+			mu.Lock()
+			rows = append(rows, targetRows...)
+			mu.Unlock()
 
-		for _, cursor := range repos.Edges {
-			vars["after"] = cursor.Cursor
-		}
+			fmt.Println("Finished with", target.Org)
+			return nil
+		})
+	}
 
-		pageNumber += 1
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].CreatedDate.Unix() < rows[j].CreatedDate.Unix()
+	})
+
+	return rows, nil
 }
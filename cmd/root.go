@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	cfgFile     string
+	format      string
+	webhookURL  string
+	concurrency int
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "findorgpulls",
+	Short: "Report open pull requests across an org's repos",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFindOrgPulls(cmd)
+	},
+}
+
+// Execute runs the root command, exiting the process on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default $HOME/.findorgpulls.yaml)")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 5, "max number of targets to scan concurrently")
+	rootCmd.Flags().StringVar(&format, "format", "text", "output format: text, markdown, html, csv, json, or webhook")
+	rootCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "POST the rendered report to this Slack/Discord webhook URL instead of printing it")
+	cobra.OnInitialize(initConfig)
+}
+
+func initConfig() {
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		home, err := os.UserHomeDir()
+		cobra.CheckErr(err)
+		viper.AddConfigPath(home)
+		viper.SetConfigType("yaml")
+		viper.SetConfigName(".findorgpulls")
+	}
+
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			fmt.Fprintln(os.Stderr, "error reading config:", err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"findorgpulls/lib"
+)
+
+// Scanner owns a Config and caches the result of its last Scan. The
+// CLI calls Scan once and prints the result; the serve command's
+// ticker loop calls it on an interval and serves the cached rows
+// in between, so /prs and /metrics never hit a forge per-request.
+type Scanner struct {
+	cfg *lib.Config
+
+	mu        sync.RWMutex
+	rows      []*lib.Row
+	scannedAt time.Time
+}
+
+// NewScanner builds a Scanner for cfg.
+func NewScanner(cfg *lib.Config) *Scanner {
+	return &Scanner{cfg: cfg}
+}
+
+// Scan walks every target in the Scanner's config, caches the result,
+// and returns it.
+func (s *Scanner) Scan(ctx context.Context) ([]*lib.Row, error) {
+	rows, err := scan(ctx, s.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.rows = rows
+	s.scannedAt = time.Now()
+	s.mu.Unlock()
+
+	return rows, nil
+}
+
+// Rows returns the rows from the most recently completed Scan, and
+// when it ran. scannedAt is the zero time if Scan has never succeeded.
+func (s *Scanner) Rows() ([]*lib.Row, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rows, s.scannedAt
+}
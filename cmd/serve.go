@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+
+	"findorgpulls/lib"
+)
+
+var (
+	serveAddr     string
+	serveInterval time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the org walk on a timer and expose the results over HTTP",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runServe(cmd.Context())
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to listen on")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", 15*time.Minute, "how often to re-scan every target")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(ctx context.Context) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	thresholds, err := cfg.BucketThresholds()
+	if err != nil {
+		return err
+	}
+
+	scanner := NewScanner(cfg)
+	metrics := newServerMetrics()
+
+	if _, err := scanner.Scan(ctx); err != nil {
+		fmt.Println("initial scan failed:", err)
+	}
+	metrics.update(scanner, thresholds)
+
+	go func() {
+		ticker := time.NewTicker(serveInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := scanner.Scan(ctx); err != nil {
+				fmt.Println("scan failed:", err)
+				continue
+			}
+			metrics.update(scanner, thresholds)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/prs", handlePRs(scanner))
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", handleHealthz(scanner))
+
+	fmt.Println("listening on", serveAddr)
+	return http.ListenAndServe(serveAddr, mux)
+}
+
+// handlePRs serves the most recently cached rows as JSON, narrowed by
+// the optional org/author/older_than_days query params.
+func handlePRs(scanner *Scanner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rows, _ := scanner.Rows()
+
+		org := r.URL.Query().Get("org")
+		author := r.URL.Query().Get("author")
+
+		var minAge time.Duration
+		if s := r.URL.Query().Get("older_than_days"); s != "" {
+			if days, err := strconv.Atoi(s); err == nil {
+				minAge = time.Duration(days) * 24 * time.Hour
+			}
+		}
+
+		filtered := make([]*lib.Row, 0, len(rows))
+		for _, row := range rows {
+			if org != "" && row.Org != org {
+				continue
+			}
+			if author != "" && row.PullRequest.Author.Login != author {
+				continue
+			}
+			if row.Age < minAge {
+				continue
+			}
+			filtered = append(filtered, row)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(filtered)
+	}
+}
+
+func handleHealthz(scanner *Scanner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, scannedAt := scanner.Rows(); scannedAt.IsZero() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, "no scan completed yet")
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// serverMetrics owns the Prometheus gauges/histogram the serve command
+// publishes at /metrics, refreshed after every Scanner.Scan.
+type serverMetrics struct {
+	registry *prometheus.Registry
+	openPRs  *prometheus.GaugeVec
+	prAge    *prometheus.HistogramVec
+}
+
+func newServerMetrics() *serverMetrics {
+	registry := prometheus.NewRegistry()
+
+	openPRs := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "findorgpulls_open_prs",
+		Help: "Number of open pull requests, by org/repo/age bucket.",
+	}, []string{"org", "repo", "bucket"})
+
+	prAge := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "findorgpulls_pr_age_days",
+		Help:    "Age in days of open pull requests.",
+		Buckets: []float64{1, 7, 30, 90, 180, 365},
+	}, []string{"org"})
+
+	registry.MustRegister(openPRs, prAge)
+
+	return &serverMetrics{registry: registry, openPRs: openPRs, prAge: prAge}
+}
+
+func (m *serverMetrics) update(scanner *Scanner, thresholds []lib.BucketThreshold) {
+	rows, _ := scanner.Rows()
+
+	counts := make(map[[3]string]int)
+
+	m.openPRs.Reset()
+	m.prAge.Reset()
+	for _, row := range rows {
+		key := [3]string{row.Org, row.Repo, bucketFor(row.Age, thresholds)}
+		counts[key]++
+		m.prAge.WithLabelValues(row.Org).Observe(row.Age.Hours() / 24)
+	}
+
+	for key, count := range counts {
+		m.openPRs.WithLabelValues(key[0], key[1], key[2]).Set(float64(count))
+	}
+}
+
+// bucketFor returns the label of the oldest threshold age crosses, or
+// "current" if it's newer than every configured bucket.
+func bucketFor(age time.Duration, thresholds []lib.BucketThreshold) string {
+	for _, t := range thresholds {
+		if age > t.OlderThan {
+			return t.Label
+		}
+	}
+	return "current"
+}
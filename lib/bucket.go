@@ -0,0 +1,94 @@
+package lib
+
+import "time"
+
+// BucketThreshold defines one age bucket: pull requests older than
+// OlderThan are counted under Label.
+type BucketThreshold struct {
+	OlderThan time.Duration
+	Label     string
+}
+
+// DefaultBucketThresholds mirrors the buckets findorgpulls has always
+// reported, oldest first.
+var DefaultBucketThresholds = []BucketThreshold{
+	{OlderThan: 365 * 24 * time.Hour, Label: "one year"},
+	{OlderThan: 6 * 30 * 24 * time.Hour, Label: "six months"},
+	{OlderThan: 3 * 30 * 24 * time.Hour, Label: "three months"},
+	{OlderThan: 30 * 24 * time.Hour, Label: "one month"},
+	{OlderThan: 7 * 24 * time.Hour, Label: "one week"},
+}
+
+// BucketCount is one bucket's label and how many pull requests fell
+// into it.
+type BucketCount struct {
+	Label string
+	Count int
+}
+
+type bucketCounter struct {
+	BucketThreshold
+	found bool
+	count int
+}
+
+// BucketPolicy buckets pull requests by age. Unlike the package-level
+// `buckets` slice it replaces, it owns its counters so a fresh
+// BucketPolicy can be built per run, keeping the scan reentrant.
+type BucketPolicy struct {
+	counters []*bucketCounter
+}
+
+// NewBucketPolicy builds a BucketPolicy from thresholds, which must be
+// sorted oldest-first (as DefaultBucketThresholds is).
+func NewBucketPolicy(thresholds []BucketThreshold) *BucketPolicy {
+	policy := &BucketPolicy{}
+	for _, t := range thresholds {
+		policy.counters = append(policy.counters, &bucketCounter{BucketThreshold: t})
+	}
+	return policy
+}
+
+// Label tallies a pull request of the given age into its bucket and
+// returns the "Older than X" heading the first time that bucket is
+// reached. It returns "" on every later call for that bucket, or if
+// age doesn't cross any configured threshold. Callers processing pull
+// requests oldest-first get one heading per bucket, matching the
+// original report layout.
+func (p *BucketPolicy) Label(age time.Duration) string {
+	for _, counter := range p.counters {
+		if age <= counter.OlderThan {
+			continue
+		}
+
+		counter.count++
+
+		if counter.found {
+			return ""
+		}
+
+		counter.found = true
+		return "Older than " + counter.Label
+	}
+
+	return ""
+}
+
+// Counts returns each bucket's label and tally, oldest first.
+func (p *BucketPolicy) Counts() []BucketCount {
+	counts := make([]BucketCount, len(p.counters))
+	for i, counter := range p.counters {
+		counts[i] = BucketCount{Label: counter.Label, Count: counter.count}
+	}
+	return counts
+}
+
+// NewestLabel is the label of the youngest configured bucket, used to
+// describe pull requests that didn't fall into any bucket ("Newer
+// than X").
+func (p *BucketPolicy) NewestLabel() string {
+	if len(p.counters) == 0 {
+		return ""
+	}
+	return p.counters[len(p.counters)-1].Label
+}
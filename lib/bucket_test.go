@@ -0,0 +1,77 @@
+package lib
+
+import (
+	"testing"
+	"time"
+)
+
+func testThresholds() []BucketThreshold {
+	return []BucketThreshold{
+		{OlderThan: 30 * 24 * time.Hour, Label: "one month"},
+		{OlderThan: 7 * 24 * time.Hour, Label: "one week"},
+	}
+}
+
+func TestBucketPolicyLabel(t *testing.T) {
+	policy := NewBucketPolicy(testThresholds())
+
+	cases := []struct {
+		name string
+		age  time.Duration
+		want string
+	}{
+		{"newer than every bucket", 1 * 24 * time.Hour, ""},
+		{"first crossing of one week", 10 * 24 * time.Hour, "Older than one week"},
+		{"second pull request in one week bucket", 12 * 24 * time.Hour, ""},
+		{"first crossing of one month", 40 * 24 * time.Hour, "Older than one month"},
+		{"second pull request in one month bucket", 45 * 24 * time.Hour, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := policy.Label(tc.age); got != tc.want {
+				t.Errorf("Label(%s) = %q, want %q", tc.age, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyCounts(t *testing.T) {
+	policy := NewBucketPolicy(testThresholds())
+
+	ages := []time.Duration{
+		1 * 24 * time.Hour,  // newer than every bucket
+		10 * 24 * time.Hour, // one week
+		12 * 24 * time.Hour, // one week
+		40 * 24 * time.Hour, // one month
+	}
+	for _, age := range ages {
+		policy.Label(age)
+	}
+
+	counts := policy.Counts()
+	want := []BucketCount{
+		{Label: "one month", Count: 1},
+		{Label: "one week", Count: 2},
+	}
+
+	if len(counts) != len(want) {
+		t.Fatalf("Counts() = %+v, want %+v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("Counts()[%d] = %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+
+	if got := policy.NewestLabel(); got != "one week" {
+		t.Errorf("NewestLabel() = %q, want %q", got, "one week")
+	}
+}
+
+func TestBucketPolicyNewestLabelEmpty(t *testing.T) {
+	policy := NewBucketPolicy(nil)
+	if got := policy.NewestLabel(); got != "" {
+		t.Errorf("NewestLabel() on empty policy = %q, want \"\"", got)
+	}
+}
@@ -0,0 +1,353 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
+)
+
+// repoPageSize and prPageSize bound a single GraphQL page. They're kept
+// well under GitHub's node-count limits so a page of repos each carrying
+// a page of pull requests doesn't trip the query cost limiter.
+const (
+	repoPageSize = 50
+	prPageSize   = 50
+)
+
+// walkConcurrency bounds how many repos' remaining pull-request pages
+// WalkOrg fetches at once. Each org repo page can turn up repoPageSize
+// repos that all need further pagination, and fetching those one at a
+// time made an org with hundreds of repos effectively single-threaded.
+const walkConcurrency = 5
+
+// Client talks to a single GitHub (or GitHub Enterprise) instance over
+// its v4 GraphQL API, and fully paginates rather than truncating
+// results.
+type Client struct {
+	v4 *githubv4.Client
+}
+
+// NewClient builds a Client authenticated with token. baseURL, when
+// non-empty, points the client at a GitHub Enterprise instance (e.g.
+// "https://ghe.example.com/api/graphql") instead of github.com.
+func NewClient(ctx context.Context, token, baseURL string) (*Client, error) {
+	httpClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	httpClient.Transport = &rateLimitTransport{base: httpClient.Transport}
+
+	v4 := githubv4.NewClient(httpClient)
+	if baseURL != "" {
+		v4 = githubv4.NewEnterpriseClient(baseURL, httpClient)
+	}
+
+	return &Client{v4: v4}, nil
+}
+
+// maxRetries bounds how many times rateLimitTransport will retry a
+// single request after a 502/503 or an abuse-detection 403.
+const maxRetries = 3
+
+// rateLimitTransport makes every request respect the rate-limit
+// headers GitHub returns, waits on a per-host rate.Limiter shared with
+// every other Client and Provider talking to that host, and retries
+// 502/503/abuse-detection responses with jittered backoff rather than
+// failing the walk outright.
+type rateLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	limiter := limiterFor(req.Host)
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, fmt.Errorf("failed to rewind request body for retry: %w", bodyErr)
+			}
+			req.Body = body
+		}
+
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err = base.RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		if wait := retryAfterDuration(resp); wait > 0 {
+			if attempt >= maxRetries {
+				return resp, nil
+			}
+			resp.Body.Close()
+			time.Sleep(wait + jitter())
+			continue
+		}
+
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				if wait := time.Until(time.Unix(reset, 0)); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+		}
+
+		if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+			resp.Body.Close()
+			time.Sleep(backoff(attempt) + jitter())
+			continue
+		}
+
+		return resp, nil
+	}
+}
+
+func retryAfterDuration(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable
+}
+
+func backoff(attempt int) time.Duration {
+	return (1 << attempt) * 200 * time.Millisecond
+}
+
+func jitter() time.Duration {
+	return time.Duration(rand.Int63n(int64(100 * time.Millisecond)))
+}
+
+// orgPullRequestsQuery walks an org's repositories in pages of
+// repoPageSize, pulling up to prPageSize open pull requests per repo
+// along the way.
+type orgPullRequestsQuery struct {
+	Organization struct {
+		Login        githubv4.String
+		Repositories struct {
+			Nodes []struct {
+				Name         githubv4.String
+				PullRequests struct {
+					Nodes    []pullRequestNode
+					PageInfo pageInfo
+				} `graphql:"pullRequests(first: $prPageSize, states: OPEN, orderBy: {field: CREATED_AT, direction: ASC})"`
+			}
+			PageInfo pageInfo
+		} `graphql:"repositories(first: $repoPageSize, orderBy: {field: NAME, direction: ASC}, after: $repoCursor)"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// repoPullRequestsQuery continues paginating a single repo's pull
+// requests once a repo's first page (fetched above) turns out to have
+// more.
+type repoPullRequestsQuery struct {
+	Repository struct {
+		PullRequests struct {
+			Nodes    []pullRequestNode
+			PageInfo pageInfo
+		} `graphql:"pullRequests(first: $prPageSize, states: OPEN, orderBy: {field: CREATED_AT, direction: ASC}, after: $prCursor)"`
+	} `graphql:"repository(owner: $org, name: $repo)"`
+}
+
+type pullRequestNode struct {
+	Number      githubv4.Int
+	Title       githubv4.String
+	Url         githubv4.String
+	CreatedAt   githubv4.DateTime
+	IsDraft     githubv4.Boolean
+	BaseRefName githubv4.String
+	Author      struct {
+		Login githubv4.String
+	}
+	Labels struct {
+		Nodes []struct {
+			Name githubv4.String
+		}
+	} `graphql:"labels(first: 10)"`
+}
+
+type pageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+func (n pullRequestNode) toPullRequest() *PullRequest {
+	labels := make([]string, len(n.Labels.Nodes))
+	for i, label := range n.Labels.Nodes {
+		labels[i] = string(label.Name)
+	}
+
+	return &PullRequest{
+		Number:     int64(n.Number),
+		Title:      string(n.Title),
+		URL:        string(n.Url),
+		Draft:      bool(n.IsDraft),
+		BaseBranch: string(n.BaseRefName),
+		Labels:     labels,
+		Author:     Author{Login: string(n.Author.Login)},
+		CreatedAt:  n.CreatedAt.Time,
+	}
+}
+
+// WalkOrg fetches every open pull request across every repo in org,
+// following cursors until GitHub reports no more pages at either level.
+func (c *Client) WalkOrg(ctx context.Context, org string) (*Organization, error) {
+	organization := &Organization{Login: org}
+
+	var repoCursor githubv4.String
+	hasRepoCursor := false
+
+	for {
+		var query orgPullRequestsQuery
+		vars := map[string]interface{}{
+			"org":          githubv4.String(org),
+			"repoPageSize": githubv4.Int(repoPageSize),
+			"prPageSize":   githubv4.Int(prPageSize),
+			"repoCursor":   (*githubv4.String)(nil),
+		}
+		if hasRepoCursor {
+			vars["repoCursor"] = githubv4.NewString(repoCursor)
+		}
+
+		if err := c.v4.Query(ctx, &query, vars); err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", org, err)
+		}
+
+		repos := make([]*Repository, len(query.Organization.Repositories.Nodes))
+		var queue []remainingPagesWork
+
+		for i, repoNode := range query.Organization.Repositories.Nodes {
+			repo := &Repository{Name: string(repoNode.Name)}
+			for _, prNode := range repoNode.PullRequests.Nodes {
+				repo.PullRequests = append(repo.PullRequests, prNode.toPullRequest())
+			}
+			repos[i] = repo
+
+			if repoNode.PullRequests.PageInfo.HasNextPage {
+				queue = append(queue, remainingPagesWork{repo: repo, cursor: repoNode.PullRequests.PageInfo.EndCursor})
+			}
+		}
+
+		if err := c.drainRemainingPagesQueue(ctx, org, queue); err != nil {
+			return nil, err
+		}
+
+		organization.Repositories = append(organization.Repositories, repos...)
+
+		if !query.Organization.Repositories.PageInfo.HasNextPage {
+			return organization, nil
+		}
+
+		repoCursor = query.Organization.Repositories.PageInfo.EndCursor
+		hasRepoCursor = true
+	}
+}
+
+// remainingPagesWork is one repo's remaining-pull-request-pages fetch,
+// queued by WalkOrg and picked up by drainRemainingPagesQueue's worker
+// pool.
+type remainingPagesWork struct {
+	repo   *Repository
+	cursor githubv4.String
+}
+
+// drainRemainingPagesQueue fetches every repo's remaining pull-request
+// pages, bounding how many repos are paginated at once to
+// walkConcurrency rather than pulling every repo's pages sequentially
+// or unboundedly in parallel. A failing fetch cancels the rest of the
+// queue and its error is returned.
+func (c *Client) drainRemainingPagesQueue(ctx context.Context, org string, queue []remainingPagesWork) error {
+	if len(queue) == 0 {
+		return nil
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	work := make(chan remainingPagesWork)
+
+	g.Go(func() error {
+		defer close(work)
+		for _, item := range queue {
+			select {
+			case work <- item:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	})
+
+	workers := walkConcurrency
+	if workers > len(queue) {
+		workers = len(queue)
+	}
+
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			for item := range work {
+				more, err := c.walkRemainingPullRequests(ctx, org, item.repo.Name, item.cursor)
+				if err != nil {
+					return err
+				}
+				item.repo.PullRequests = append(item.repo.PullRequests, more...)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// walkRemainingPullRequests continues a single repo's pull-request
+// pagination past the page already fetched by WalkOrg.
+func (c *Client) walkRemainingPullRequests(ctx context.Context, org, repo string, cursor githubv4.String) ([]*PullRequest, error) {
+	var pullRequests []*PullRequest
+
+	for {
+		var query repoPullRequestsQuery
+		vars := map[string]interface{}{
+			"org":        githubv4.String(org),
+			"repo":       githubv4.String(repo),
+			"prPageSize": githubv4.Int(prPageSize),
+			"prCursor":   githubv4.NewString(cursor),
+		}
+
+		if err := c.v4.Query(ctx, &query, vars); err != nil {
+			return nil, fmt.Errorf("failed to query %s/%s pull requests: %w", org, repo, err)
+		}
+
+		for _, prNode := range query.Repository.PullRequests.Nodes {
+			pullRequests = append(pullRequests, prNode.toPullRequest())
+		}
+
+		if !query.Repository.PullRequests.PageInfo.HasNextPage {
+			return pullRequests, nil
+		}
+
+		cursor = query.Repository.PullRequests.PageInfo.EndCursor
+	}
+}
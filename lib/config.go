@@ -0,0 +1,73 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BucketConfig is a single age bucket as written in the config file,
+// e.g. {older_than: 90d, label: "three months"}.
+type BucketConfig struct {
+	OlderThan string `mapstructure:"older_than"`
+	Label     string `mapstructure:"label"`
+}
+
+// Threshold parses OlderThan into a BucketThreshold. OlderThan accepts
+// Go duration strings ("2160h") as well as a "<n>d" shorthand for days,
+// since config authors think in days, not hours.
+func (b BucketConfig) Threshold() (BucketThreshold, error) {
+	d, err := parseAge(b.OlderThan)
+	if err != nil {
+		return BucketThreshold{}, fmt.Errorf("invalid older_than %q for bucket %q: %w", b.OlderThan, b.Label, err)
+	}
+	return BucketThreshold{OlderThan: d, Label: b.Label}, nil
+}
+
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+// Config is the root of ~/.findorgpulls.yaml (or whatever the user
+// names it): the forges to scan, the age buckets to report, and the
+// filter to apply to every pull request found.
+type Config struct {
+	Targets []Target       `mapstructure:"targets"`
+	Buckets []BucketConfig `mapstructure:"buckets"`
+	Filter  RepoFilter     `mapstructure:"filter"`
+}
+
+// BucketThresholds parses Buckets into BucketThreshold values, falling
+// back to DefaultBucketThresholds when the config declares none.
+// Buckets can be written in any order in the config file; the result
+// is sorted oldest-first, as BucketPolicy requires.
+func (c *Config) BucketThresholds() ([]BucketThreshold, error) {
+	if len(c.Buckets) == 0 {
+		return DefaultBucketThresholds, nil
+	}
+
+	thresholds := make([]BucketThreshold, len(c.Buckets))
+	for i, b := range c.Buckets {
+		threshold, err := b.Threshold()
+		if err != nil {
+			return nil, err
+		}
+		thresholds[i] = threshold
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i].OlderThan > thresholds[j].OlderThan
+	})
+
+	return thresholds, nil
+}
@@ -0,0 +1,94 @@
+package lib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"1d", 24 * time.Hour, false},
+		{"1.5d", 36 * time.Hour, false},
+		{"2160h", 2160 * time.Hour, false},
+		{"not-a-duration", 0, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseAge(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseAge(%q) = %s, nil, want error", tc.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAge(%q) returned unexpected error: %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Errorf("parseAge(%q) = %s, want %s", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBucketConfigThreshold(t *testing.T) {
+	b := BucketConfig{OlderThan: "14d", Label: "two weeks"}
+
+	threshold, err := b.Threshold()
+	if err != nil {
+		t.Fatalf("Threshold() returned unexpected error: %v", err)
+	}
+
+	want := BucketThreshold{OlderThan: 14 * 24 * time.Hour, Label: "two weeks"}
+	if threshold != want {
+		t.Errorf("Threshold() = %+v, want %+v", threshold, want)
+	}
+}
+
+func TestBucketConfigThresholdInvalidOlderThan(t *testing.T) {
+	b := BucketConfig{OlderThan: "soon", Label: "bad"}
+
+	if _, err := b.Threshold(); err == nil {
+		t.Fatal("Threshold() with an invalid older_than should return an error")
+	}
+}
+
+func TestConfigBucketThresholdsDefaultsWhenEmpty(t *testing.T) {
+	cfg := &Config{}
+
+	thresholds, err := cfg.BucketThresholds()
+	if err != nil {
+		t.Fatalf("BucketThresholds() returned unexpected error: %v", err)
+	}
+	if len(thresholds) != len(DefaultBucketThresholds) {
+		t.Fatalf("BucketThresholds() = %+v, want DefaultBucketThresholds", thresholds)
+	}
+}
+
+func TestConfigBucketThresholdsSortsOldestFirst(t *testing.T) {
+	cfg := &Config{
+		Buckets: []BucketConfig{
+			{OlderThan: "7d", Label: "one week"},
+			{OlderThan: "90d", Label: "three months"},
+			{OlderThan: "30d", Label: "one month"},
+		},
+	}
+
+	thresholds, err := cfg.BucketThresholds()
+	if err != nil {
+		t.Fatalf("BucketThresholds() returned unexpected error: %v", err)
+	}
+
+	want := []string{"three months", "one month", "one week"}
+	for i, label := range want {
+		if thresholds[i].Label != label {
+			t.Errorf("thresholds[%d].Label = %q, want %q (thresholds: %+v)", i, thresholds[i].Label, label, thresholds)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package lib
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// RepoFilter narrows which pull requests findorgpulls reports on. A
+// zero value field means "don't filter on this".
+type RepoFilter struct {
+	Author      string   `mapstructure:"author"`
+	Label       string   `mapstructure:"label"`
+	Draft       *bool    `mapstructure:"draft"`
+	BaseBranch  string   `mapstructure:"base_branch"`
+	ExcludeRepo []string `mapstructure:"exclude_repo"`
+}
+
+// Apply returns the subset of pullRequests that pass every configured
+// filter.
+func (f RepoFilter) Apply(pullRequests []*PullRequest) []*PullRequest {
+	var filtered []*PullRequest
+
+	for _, pr := range pullRequests {
+		if f.Author != "" && !strings.EqualFold(pr.Author.Login, f.Author) {
+			continue
+		}
+		if f.Label != "" && !hasLabel(pr.Labels, f.Label) {
+			continue
+		}
+		if f.Draft != nil && pr.Draft != *f.Draft {
+			continue
+		}
+		if f.BaseBranch != "" && pr.BaseBranch != f.BaseBranch {
+			continue
+		}
+		if matchesAny(f.ExcludeRepo, pr.Repo) {
+			continue
+		}
+
+		filtered = append(filtered, pr)
+	}
+
+	return filtered
+}
+
+func hasLabel(labels []string, want string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(label, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(globs []string, name string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
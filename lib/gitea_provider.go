@@ -0,0 +1,102 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+const giteaPageSize = 50
+
+// GiteaProvider adapts a Gitea instance's REST API to the Provider
+// interface.
+type GiteaProvider struct {
+	client *gitea.Client
+}
+
+// NewGiteaProvider builds a GiteaProvider authenticated with token
+// against the Gitea instance at baseURL.
+func NewGiteaProvider(baseURL, token string) (*GiteaProvider, error) {
+	httpClient := &http.Client{Transport: &rateLimitTransport{}}
+
+	client, err := gitea.NewClient(baseURL, gitea.SetToken(token), gitea.SetHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gitea client: %w", err)
+	}
+
+	return &GiteaProvider{client: client}, nil
+}
+
+func (p *GiteaProvider) ListOpenPullRequests(ctx context.Context, org string) ([]*PullRequest, error) {
+	p.client.SetContext(ctx)
+
+	var pullRequests []*PullRequest
+
+	page := 1
+	for {
+		repos, _, err := p.client.ListOrgRepos(org, gitea.ListOrgReposOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: giteaPageSize},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s repos: %w", org, err)
+		}
+
+		for _, repo := range repos {
+			repoPullRequests, err := p.listRepoPullRequests(org, repo.Name)
+			if err != nil {
+				return nil, err
+			}
+			pullRequests = append(pullRequests, repoPullRequests...)
+		}
+
+		if len(repos) < giteaPageSize {
+			return pullRequests, nil
+		}
+		page++
+	}
+}
+
+func (p *GiteaProvider) listRepoPullRequests(org, repo string) ([]*PullRequest, error) {
+	var pullRequests []*PullRequest
+
+	page := 1
+	for {
+		prs, _, err := p.client.ListRepoPullRequests(org, repo, gitea.ListPullRequestsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: giteaPageSize},
+			State:       gitea.StateOpen,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s/%s pull requests: %w", org, repo, err)
+		}
+
+		for _, pr := range prs {
+			pullRequest := &PullRequest{
+				Number: pr.Index,
+				Title:  pr.Title,
+				URL:    pr.HTMLURL,
+				Repo:   repo,
+				Draft:  pr.Draft,
+			}
+			if pr.Poster != nil {
+				pullRequest.Author = Author{Login: pr.Poster.UserName}
+			}
+			if pr.Created != nil {
+				pullRequest.CreatedAt = *pr.Created
+			}
+			if pr.Base != nil {
+				pullRequest.BaseBranch = pr.Base.Ref
+			}
+			for _, label := range pr.Labels {
+				pullRequest.Labels = append(pullRequest.Labels, label.Name)
+			}
+			pullRequests = append(pullRequests, pullRequest)
+		}
+
+		if len(prs) < giteaPageSize {
+			return pullRequests, nil
+		}
+		page++
+	}
+}
@@ -0,0 +1,75 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const gitlabPageSize = 50
+
+// GitLabProvider adapts a GitLab instance's REST API to the Provider
+// interface, mapping merge requests onto the PullRequest model.
+type GitLabProvider struct {
+	client *gitlab.Client
+}
+
+// NewGitLabProvider builds a GitLabProvider authenticated with token
+// against the GitLab instance at baseURL (empty for gitlab.com).
+func NewGitLabProvider(baseURL, token string) (*GitLabProvider, error) {
+	httpClient := &http.Client{Transport: &rateLimitTransport{}}
+	opts := []gitlab.ClientOptionFunc{gitlab.WithHTTPClient(httpClient)}
+	if baseURL != "" {
+		opts = append(opts, gitlab.WithBaseURL(baseURL))
+	}
+
+	client, err := gitlab.NewClient(token, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gitlab client: %w", err)
+	}
+
+	return &GitLabProvider{client: client}, nil
+}
+
+func (p *GitLabProvider) ListOpenPullRequests(ctx context.Context, group string) ([]*PullRequest, error) {
+	var pullRequests []*PullRequest
+
+	opened := "opened"
+	opts := &gitlab.ListGroupMergeRequestsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: gitlabPageSize, Page: 1},
+		State:       &opened,
+	}
+
+	for {
+		mergeRequests, resp, err := p.client.MergeRequests.ListGroupMergeRequests(group, opts, gitlab.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s merge requests: %w", group, err)
+		}
+
+		for _, mr := range mergeRequests {
+			pullRequest := &PullRequest{
+				Number:     int64(mr.IID),
+				Title:      mr.Title,
+				URL:        mr.WebURL,
+				Repo:       mr.References.Full,
+				Draft:      mr.Draft,
+				BaseBranch: mr.TargetBranch,
+				Labels:     mr.Labels,
+			}
+			if mr.Author != nil {
+				pullRequest.Author = Author{Login: mr.Author.Username}
+			}
+			if mr.CreatedAt != nil {
+				pullRequest.CreatedAt = *mr.CreatedAt
+			}
+			pullRequests = append(pullRequests, pullRequest)
+		}
+
+		if resp.NextPage == 0 {
+			return pullRequests, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -1,70 +1,37 @@
 package lib
 
-type Extension struct {
-	Code         string `json:"code"`
-	TypeName     string `json:"typeName"`
-	VariableName string `json:"pageSize"`
-}
-
-type Location struct {
-	Line   int `json:"line"`
-	Column int `json:"column"`
-}
-
-type GraphQLError struct {
-	Path       []string   `json:"path"`
-	Extensions *Extension `json:"extensions"`
-	Locations  []Location `json:"locations"`
-	Message    string     `json:"message"`
-}
-
-func (e *GraphQLError) String() string {
-	return e.Message
-}
-
-type ErrorResponse struct {
-	Errors []GraphQLError `json:"errors"`
-}
+import "time"
 
+// Author is the user who opened a pull (or merge) request.
 type Author struct {
-	Login string `json:"login"`
+	Login string
 }
 
+// PullRequest is a single open pull (or merge) request as returned by a
+// Provider. Repo is the repository or project it belongs to; Providers
+// that fetch PRs grouped by repo (like GitHubProvider) fill it in when
+// flattening their results.
 type PullRequest struct {
-	Number    int64  `json:"number"`
-	Title     string `json:"title"`
-	Author    Author `json:"author"`
-	CreatedAt string `json:"createdAt"`
-}
-
-type PullRequests struct {
-	Nodes []*PullRequest `json:"nodes"`
-}
-
+	Number     int64
+	Title      string
+	URL        string
+	Author     Author
+	CreatedAt  time.Time
+	Repo       string
+	Draft      bool
+	BaseBranch string
+	Labels     []string
+}
+
+// Repository groups the open pull requests found in a single repo.
 type Repository struct {
-	Name         string       `json:"name"`
-	PullRequests PullRequests `json:"pullRequests"`
-}
-
-type Cursor struct {
-	Cursor string `json:"cursor"`
-}
-
-type Repositories struct {
-	TotalCount int64         `json:"totalCount"`
-	Nodes      []*Repository `json:"nodes"`
-	Edges      []*Cursor     `json:"edges"`
+	Name         string
+	PullRequests []*PullRequest
 }
 
+// Organization is the result of walking a single org: its repos and,
+// for each, its open pull requests.
 type Organization struct {
-	Login        string        `json:"login"`
-	Repositories *Repositories `json:"repositories"`
-}
-
-type Data struct {
-	Organization *Organization `json:"organization"`
-}
-
-type Response struct {
-	Data *Data `json:"data"`
+	Login        string
+	Repositories []*Repository
 }
@@ -0,0 +1,69 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+)
+
+// Target describes a single forge to scan: its kind (github, gitea, or
+// gitlab), the base URL of its API (empty for the public host), the
+// token to authenticate with, and the org or group to walk.
+type Target struct {
+	Kind    string `mapstructure:"kind"`
+	BaseURL string `mapstructure:"base_url"`
+	Token   string `mapstructure:"token"`
+	Org     string `mapstructure:"org"`
+}
+
+// Provider is a forge backend capable of listing the open pull (or
+// merge) requests for an org/group. GitHub, Gitea, and GitLab each
+// implement it so findorgpulls can fan out across heterogeneous
+// targets and unify the results into a single report.
+type Provider interface {
+	ListOpenPullRequests(ctx context.Context, org string) ([]*PullRequest, error)
+}
+
+// NewProvider builds the Provider matching target.Kind. An empty Kind
+// defaults to "github" for backwards compatibility.
+func NewProvider(ctx context.Context, target Target) (Provider, error) {
+	switch target.Kind {
+	case "", "github":
+		client, err := NewClient(ctx, target.Token, target.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		return &GitHubProvider{client: client}, nil
+
+	case "gitea":
+		return NewGiteaProvider(target.BaseURL, target.Token)
+
+	case "gitlab":
+		return NewGitLabProvider(target.BaseURL, target.Token)
+
+	default:
+		return nil, fmt.Errorf("unknown provider kind %q", target.Kind)
+	}
+}
+
+// GitHubProvider adapts Client to the Provider interface, flattening
+// WalkOrg's per-repo grouping into a single list.
+type GitHubProvider struct {
+	client *Client
+}
+
+func (p *GitHubProvider) ListOpenPullRequests(ctx context.Context, org string) ([]*PullRequest, error) {
+	organization, err := p.client.WalkOrg(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	var pullRequests []*PullRequest
+	for _, repo := range organization.Repositories {
+		for _, pr := range repo.PullRequests {
+			pr.Repo = repo.Name
+			pullRequests = append(pullRequests, pr)
+		}
+	}
+
+	return pullRequests, nil
+}
@@ -0,0 +1,20 @@
+package lib
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// hostLimiters shares one rate.Limiter per host across every Client
+// and Provider in the process, so concurrent scans of the same forge
+// stay under one shared budget instead of each racing the API
+// independently.
+var hostLimiters sync.Map // host (string) -> *rate.Limiter
+
+// limiterFor returns the shared limiter for host, creating one with a
+// conservative default burst the first time host is seen.
+func limiterFor(host string) *rate.Limiter {
+	limiter, _ := hostLimiters.LoadOrStore(host, rate.NewLimiter(rate.Limit(10), 10))
+	return limiter.(*rate.Limiter)
+}
@@ -0,0 +1,175 @@
+package lib
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Renderer formats a set of Rows for a particular output target. The
+// CLI picks one with --format; the scan itself doesn't change based on
+// which Renderer is wired up.
+type Renderer interface {
+	Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error
+}
+
+// Renderers maps each --format value to its Renderer.
+var Renderers = map[string]Renderer{
+	"text":     TextRenderer{},
+	"markdown": MarkdownRenderer{},
+	"html":     HTMLRenderer{},
+	"csv":      CSVRenderer{},
+	"json":     JSONRenderer{},
+	"webhook":  WebhookRenderer{},
+}
+
+// TextRenderer reproduces the plain-text console report findorgpulls
+// has always printed: an "Older than X" heading the first time a row
+// crosses into a new bucket, then a one-line summary per row.
+type TextRenderer struct{}
+
+func (TextRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	fmt.Fprintf(w, "Found %d open pull requests\n", len(rows))
+
+	policy := NewBucketPolicy(thresholds)
+	for _, row := range rows {
+		if label := policy.Label(row.Age); label != "" {
+			fmt.Fprintln(w, label)
+		}
+		fmt.Fprint(w, row.String())
+	}
+
+	fmt.Fprintf(w, "\n\nSummary of %d PRs\n", len(rows))
+
+	var counted int
+	for _, b := range policy.Counts() {
+		counted += b.Count
+		fmt.Fprintf(w, "- %s: %d PRs\n", b.Label, b.Count)
+	}
+	fmt.Fprintf(w, "- Newer than %s: %d\n", policy.NewestLabel(), len(rows)-counted)
+
+	return nil
+}
+
+// MarkdownRenderer groups rows under a heading per bucket, ready to
+// paste into a weekly digest issue.
+type MarkdownRenderer struct{}
+
+func (MarkdownRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	policy := NewBucketPolicy(thresholds)
+	newerHeading := false
+
+	for _, row := range rows {
+		if label := policy.Label(row.Age); label != "" {
+			fmt.Fprintf(w, "\n## %s\n\n", label)
+		} else if !newerHeading && len(thresholds) > 0 && row.Age <= thresholds[len(thresholds)-1].OlderThan {
+			newerHeading = true
+			fmt.Fprintf(w, "\n## Newer than %s\n\n", policy.NewestLabel())
+		}
+		fmt.Fprintf(w, "- [%s/%s#%d](%s): %s <%s>\n",
+			row.Org, row.Repo, row.PullRequest.Number, row.PullRequest.URL,
+			row.PullRequest.Title, row.PullRequest.Author.Login)
+	}
+
+	return nil
+}
+
+// HTMLRenderer renders rows as a single table, one row per pull
+// request.
+type HTMLRenderer struct{}
+
+func (HTMLRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	fmt.Fprintln(w, "<table>")
+	fmt.Fprintln(w, "<tr><th>Age (days)</th><th>Org</th><th>Repo</th><th>Title</th><th>Author</th></tr>")
+
+	for _, row := range rows {
+		fmt.Fprintf(w, "<tr><td>%d</td><td>%s</td><td>%s</td><td><a href=\"%s\">%s</a></td><td>%s</td></tr>\n",
+			int(row.Age.Hours()/24),
+			html.EscapeString(row.Org),
+			html.EscapeString(row.Repo),
+			html.EscapeString(row.PullRequest.URL),
+			html.EscapeString(row.PullRequest.Title),
+			html.EscapeString(row.PullRequest.Author.Login),
+		)
+	}
+
+	fmt.Fprintln(w, "</table>")
+	return nil
+}
+
+// CSVRenderer renders rows as CSV, one row per pull request.
+type CSVRenderer struct{}
+
+func (CSVRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"org", "repo", "number", "title", "author", "created_at", "age_days", "url"}); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		record := []string{
+			row.Org,
+			row.Repo,
+			strconv.FormatInt(row.PullRequest.Number, 10),
+			row.PullRequest.Title,
+			row.PullRequest.Author.Login,
+			row.CreatedDate.Format(time.RFC3339),
+			strconv.Itoa(int(row.Age.Hours() / 24)),
+			row.PullRequest.URL,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// JSONRenderer streams rows as newline-delimited JSON, one object per
+// pull request.
+type JSONRenderer struct{}
+
+func (JSONRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	enc := json.NewEncoder(w)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Text string `json:"text"`
+}
+
+// WebhookRenderer builds a Slack/Discord-compatible payload
+// summarizing the scan, one bucket count per line. Both platforms
+// accept {"text": "..."} as a minimal incoming-webhook body.
+type WebhookRenderer struct{}
+
+func (WebhookRenderer) Render(w io.Writer, rows []*Row, thresholds []BucketThreshold) error {
+	policy := NewBucketPolicy(thresholds)
+	for _, row := range rows {
+		policy.Label(row.Age)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "*%d open pull requests*\n", len(rows))
+
+	var counted int
+	for _, b := range policy.Counts() {
+		counted += b.Count
+		fmt.Fprintf(&text, "- %s: %d\n", b.Label, b.Count)
+	}
+	fmt.Fprintf(&text, "- Newer than %s: %d\n", policy.NewestLabel(), len(rows)-counted)
+
+	return json.NewEncoder(w).Encode(webhookPayload{Text: text.String()})
+}
@@ -0,0 +1,27 @@
+package lib
+
+import (
+	"fmt"
+	"time"
+)
+
+// Row is a single open pull request annotated with its age — the
+// shape every Renderer formats.
+type Row struct {
+	Org         string
+	Repo        string
+	PullRequest *PullRequest
+	CreatedDate time.Time
+	Age         time.Duration
+}
+
+// String renders a Row as a plain one-line summary, suitable for a
+// terminal.
+func (row *Row) String() string {
+	return fmt.Sprintf("%d days | %s: %s <%s>\n",
+		int(row.Age.Hours()/24),
+		row.PullRequest.URL,
+		row.PullRequest.Title,
+		row.PullRequest.Author.Login,
+	)
+}